@@ -0,0 +1,375 @@
+// dtnagent.pb.go contains the Go types for the messages declared in
+// dtnagent.proto, hand-maintained in lieu of protoc-gen-go: this repo's
+// build has no protoc/protoc-gen-go toolchain wired in, so there is no
+// `go generate` step that reproduces this file from the .proto source.
+// Keep the two in sync by hand whenever either changes.
+//
+// The struct tags below follow the same `protobuf:"..."` / `protobuf_oneof:"..."`
+// grammar protoc-gen-go itself emits, which lets google.golang.org/protobuf's
+// legacy-message support derive a working message descriptor from reflection
+// alone. That's what makes these types real proto.Message implementations
+// grpc's default codec can marshal, rather than plain structs.
+package grpc
+
+import "github.com/golang/protobuf/proto"
+
+// EndpointID mirrors bpa.EndpointID. The scheme is given by which
+// scheme-specific part (SSP) is set, "dtn" or "ipn".
+type EndpointID struct {
+	// Ssp is one of *EndpointID_Dtn or *EndpointID_Ipn.
+	Ssp isEndpointID_Ssp `protobuf_oneof:"ssp"`
+}
+
+func (m *EndpointID) Reset()         { *m = EndpointID{} }
+func (m *EndpointID) String() string { return proto.CompactTextString(m) }
+func (*EndpointID) ProtoMessage()    {}
+
+func (*EndpointID) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*EndpointID_Dtn)(nil),
+		(*EndpointID_Ipn)(nil),
+	}
+}
+
+type isEndpointID_Ssp interface {
+	isEndpointID_Ssp()
+}
+
+type EndpointID_Dtn struct {
+	Dtn *DtnSSP `protobuf:"bytes,1,opt,name=dtn,proto3,oneof"`
+}
+
+type EndpointID_Ipn struct {
+	Ipn *IpnSSP `protobuf:"bytes,2,opt,name=ipn,proto3,oneof"`
+}
+
+func (*EndpointID_Dtn) isEndpointID_Ssp() {}
+func (*EndpointID_Ipn) isEndpointID_Ssp() {}
+
+func (m *EndpointID) GetDtn() *DtnSSP {
+	if x, ok := m.GetSsp().(*EndpointID_Dtn); ok {
+		return x.Dtn
+	}
+	return nil
+}
+
+func (m *EndpointID) GetIpn() *IpnSSP {
+	if x, ok := m.GetSsp().(*EndpointID_Ipn); ok {
+		return x.Ipn
+	}
+	return nil
+}
+
+func (m *EndpointID) GetSsp() isEndpointID_Ssp {
+	if m != nil {
+		return m.Ssp
+	}
+	return nil
+}
+
+// DtnSSP is the scheme-specific part of a "dtn" URI. An empty ssp denotes
+// "dtn:none".
+type DtnSSP struct {
+	Ssp string `protobuf:"bytes,1,opt,name=ssp,proto3" json:"ssp,omitempty"`
+}
+
+func (m *DtnSSP) Reset()         { *m = DtnSSP{} }
+func (m *DtnSSP) String() string { return proto.CompactTextString(m) }
+func (*DtnSSP) ProtoMessage()    {}
+
+func (m *DtnSSP) GetSsp() string {
+	if m != nil {
+		return m.Ssp
+	}
+	return ""
+}
+
+// IpnSSP is the scheme-specific part of an "ipn" URI, as defined in RFC 6260.
+type IpnSSP struct {
+	Node    uint64 `protobuf:"varint,1,opt,name=node,proto3" json:"node,omitempty"`
+	Service uint64 `protobuf:"varint,2,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (m *IpnSSP) Reset()         { *m = IpnSSP{} }
+func (m *IpnSSP) String() string { return proto.CompactTextString(m) }
+func (*IpnSSP) ProtoMessage()    {}
+
+func (m *IpnSSP) GetNode() uint64 {
+	if m != nil {
+		return m.Node
+	}
+	return 0
+}
+
+func (m *IpnSSP) GetService() uint64 {
+	if m != nil {
+		return m.Service
+	}
+	return 0
+}
+
+// PayloadBlock carries an application's payload, as a canonical block of
+// type 1.
+type PayloadBlock struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *PayloadBlock) Reset()         { *m = PayloadBlock{} }
+func (m *PayloadBlock) String() string { return proto.CompactTextString(m) }
+func (*PayloadBlock) ProtoMessage()    {}
+
+func (m *PayloadBlock) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// HopCountBlock is a canonical block of type 2, tracking the number of
+// times a bundle has been forwarded.
+type HopCountBlock struct {
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *HopCountBlock) Reset()         { *m = HopCountBlock{} }
+func (m *HopCountBlock) String() string { return proto.CompactTextString(m) }
+func (*HopCountBlock) ProtoMessage()    {}
+
+func (m *HopCountBlock) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *HopCountBlock) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// BundleAgeBlock is a canonical block of type 3, tracking a bundle's age in
+// microseconds for nodes without an accurate clock.
+type BundleAgeBlock struct {
+	Age uint64 `protobuf:"varint,1,opt,name=age,proto3" json:"age,omitempty"`
+}
+
+func (m *BundleAgeBlock) Reset()         { *m = BundleAgeBlock{} }
+func (m *BundleAgeBlock) String() string { return proto.CompactTextString(m) }
+func (*BundleAgeBlock) ProtoMessage()    {}
+
+func (m *BundleAgeBlock) GetAge() uint64 {
+	if m != nil {
+		return m.Age
+	}
+	return 0
+}
+
+// CanonicalBlock mirrors bundle.CanonicalBlock. Block types known to this
+// schema are carried natively; everything else falls back to its raw,
+// already CBOR-encoded representation.
+type CanonicalBlock struct {
+	BlockNumber       uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockControlFlags uint64 `protobuf:"varint,2,opt,name=block_control_flags,json=blockControlFlags,proto3" json:"block_control_flags,omitempty"`
+
+	// Data is one of *CanonicalBlock_Payload, *CanonicalBlock_HopCount,
+	// *CanonicalBlock_BundleAge or *CanonicalBlock_CborData.
+	Data isCanonicalBlock_Data `protobuf_oneof:"data"`
+}
+
+func (m *CanonicalBlock) Reset()         { *m = CanonicalBlock{} }
+func (m *CanonicalBlock) String() string { return proto.CompactTextString(m) }
+func (*CanonicalBlock) ProtoMessage()    {}
+
+func (*CanonicalBlock) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CanonicalBlock_Payload)(nil),
+		(*CanonicalBlock_HopCount)(nil),
+		(*CanonicalBlock_BundleAge)(nil),
+		(*CanonicalBlock_CborData)(nil),
+	}
+}
+
+func (m *CanonicalBlock) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *CanonicalBlock) GetBlockControlFlags() uint64 {
+	if m != nil {
+		return m.BlockControlFlags
+	}
+	return 0
+}
+
+type isCanonicalBlock_Data interface {
+	isCanonicalBlock_Data()
+}
+
+type CanonicalBlock_Payload struct {
+	Payload *PayloadBlock `protobuf:"bytes,3,opt,name=payload,proto3,oneof"`
+}
+
+type CanonicalBlock_HopCount struct {
+	HopCount *HopCountBlock `protobuf:"bytes,4,opt,name=hop_count,json=hopCount,proto3,oneof"`
+}
+
+type CanonicalBlock_BundleAge struct {
+	BundleAge *BundleAgeBlock `protobuf:"bytes,5,opt,name=bundle_age,json=bundleAge,proto3,oneof"`
+}
+
+type CanonicalBlock_CborData struct {
+	CborData []byte `protobuf:"bytes,6,opt,name=cbor_data,json=cborData,proto3,oneof"`
+}
+
+func (*CanonicalBlock_Payload) isCanonicalBlock_Data()   {}
+func (*CanonicalBlock_HopCount) isCanonicalBlock_Data()  {}
+func (*CanonicalBlock_BundleAge) isCanonicalBlock_Data() {}
+func (*CanonicalBlock_CborData) isCanonicalBlock_Data()  {}
+
+func (m *CanonicalBlock) GetData() isCanonicalBlock_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Bundle mirrors bundle.Bundle: the primary block's fields plus the
+// canonical blocks that make up the rest of the bundle.
+type Bundle struct {
+	BundleControlFlags        uint64      `protobuf:"varint,1,opt,name=bundle_control_flags,json=bundleControlFlags,proto3" json:"bundle_control_flags,omitempty"`
+	Destination               *EndpointID `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Source                    *EndpointID `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	ReportTo                  *EndpointID `protobuf:"bytes,4,opt,name=report_to,json=reportTo,proto3" json:"report_to,omitempty"`
+	CreationTimestampTime     uint64      `protobuf:"varint,5,opt,name=creation_timestamp_time,json=creationTimestampTime,proto3" json:"creation_timestamp_time,omitempty"`
+	CreationTimestampSequence uint64      `protobuf:"varint,6,opt,name=creation_timestamp_sequence,json=creationTimestampSequence,proto3" json:"creation_timestamp_sequence,omitempty"`
+	Lifetime                  uint64      `protobuf:"varint,7,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+
+	CanonicalBlocks []*CanonicalBlock `protobuf:"bytes,8,rep,name=canonical_blocks,json=canonicalBlocks,proto3" json:"canonical_blocks,omitempty"`
+}
+
+func (m *Bundle) Reset()         { *m = Bundle{} }
+func (m *Bundle) String() string { return proto.CompactTextString(m) }
+func (*Bundle) ProtoMessage()    {}
+
+func (m *Bundle) GetBundleControlFlags() uint64 {
+	if m != nil {
+		return m.BundleControlFlags
+	}
+	return 0
+}
+
+func (m *Bundle) GetDestination() *EndpointID {
+	if m != nil {
+		return m.Destination
+	}
+	return nil
+}
+
+func (m *Bundle) GetSource() *EndpointID {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (m *Bundle) GetReportTo() *EndpointID {
+	if m != nil {
+		return m.ReportTo
+	}
+	return nil
+}
+
+func (m *Bundle) GetCreationTimestampTime() uint64 {
+	if m != nil {
+		return m.CreationTimestampTime
+	}
+	return 0
+}
+
+func (m *Bundle) GetCreationTimestampSequence() uint64 {
+	if m != nil {
+		return m.CreationTimestampSequence
+	}
+	return 0
+}
+
+func (m *Bundle) GetLifetime() uint64 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *Bundle) GetCanonicalBlocks() []*CanonicalBlock {
+	if m != nil {
+		return m.CanonicalBlocks
+	}
+	return nil
+}
+
+type SubmitRequest struct {
+	CrcType     uint32      `protobuf:"varint,1,opt,name=crc_type,json=crcType,proto3" json:"crc_type,omitempty"`
+	Source      *EndpointID `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination *EndpointID `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	Lifetime    uint64      `protobuf:"varint,4,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+	Payload     []byte      `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+
+func (m *SubmitRequest) GetCrcType() uint32 {
+	if m != nil {
+		return m.CrcType
+	}
+	return 0
+}
+
+func (m *SubmitRequest) GetSource() *EndpointID {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (m *SubmitRequest) GetDestination() *EndpointID {
+	if m != nil {
+		return m.Destination
+	}
+	return nil
+}
+
+func (m *SubmitRequest) GetLifetime() uint64 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *SubmitRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type SubmitResponse struct {
+	BundleId string `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitResponse) ProtoMessage()    {}
+
+func (m *SubmitResponse) GetBundleId() string {
+	if m != nil {
+		return m.BundleId
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+// dtnagent_grpc.pb.go contains the client/server stubs for the DtnAgent
+// service declared in dtnagent.proto, hand-maintained alongside
+// dtnagent.pb.go for the same reason: no protoc-gen-go-grpc step runs as
+// part of this repo's build.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// DtnAgentClient is the client API for DtnAgent service.
+type DtnAgentClient interface {
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	Register(ctx context.Context, in *EndpointID, opts ...grpc.CallOption) (DtnAgent_RegisterClient, error)
+}
+
+type dtnAgentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewDtnAgentClient creates a client stub for the DtnAgent service.
+func NewDtnAgentClient(cc grpc.ClientConnInterface) DtnAgentClient {
+	return &dtnAgentClient{cc}
+}
+
+func (c *dtnAgentClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	if err := c.cc.Invoke(ctx, "/dtnagent.DtnAgent/Submit", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtnAgentClient) Register(ctx context.Context, in *EndpointID, opts ...grpc.CallOption) (DtnAgent_RegisterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DtnAgent_serviceDesc.Streams[0], "/dtnagent.DtnAgent/Register", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dtnAgentRegisterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// DtnAgent_RegisterClient is the client-side stream returned by Register.
+type DtnAgent_RegisterClient interface {
+	Recv() (*Bundle, error)
+	grpc.ClientStream
+}
+
+type dtnAgentRegisterClient struct {
+	grpc.ClientStream
+}
+
+func (x *dtnAgentRegisterClient) Recv() (*Bundle, error) {
+	m := new(Bundle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DtnAgentServer is the server API for DtnAgent service.
+type DtnAgentServer interface {
+	Submit(context.Context, *SubmitRequest) (*SubmitResponse, error)
+	Register(*EndpointID, DtnAgent_RegisterServer) error
+}
+
+// DtnAgent_RegisterServer is the server-side stream used by Register.
+type DtnAgent_RegisterServer interface {
+	Send(*Bundle) error
+	grpc.ServerStream
+}
+
+type dtnAgentRegisterServer struct {
+	grpc.ServerStream
+}
+
+func (x *dtnAgentRegisterServer) Send(m *Bundle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterDtnAgentServer registers impl as the handler for the DtnAgent
+// service on s.
+func RegisterDtnAgentServer(s *grpc.Server, impl DtnAgentServer) {
+	s.RegisterService(&_DtnAgent_serviceDesc, impl)
+}
+
+func _DtnAgent_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtnAgentServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dtnagent.DtnAgent/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtnAgentServer).Submit(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtnAgent_Register_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EndpointID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DtnAgentServer).Register(m, &dtnAgentRegisterServer{stream})
+}
+
+var _DtnAgent_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dtnagent.DtnAgent",
+	HandlerType: (*DtnAgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Submit",
+			Handler:    _DtnAgent_Submit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Register",
+			Handler:       _DtnAgent_Register_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dtnagent.proto",
+}
@@ -0,0 +1,236 @@
+// Package grpc implements a gRPC application agent API. It lets external
+// applications submit bundles and subscribe to bundles delivered for one of
+// their endpoints, as an alternative to the existing REST and Unix-socket
+// agents. Unlike those agents, this one gives language-agnostic clients a
+// first-class way to talk to a dtn7 node without re-implementing CBOR
+// bundle encoding themselves.
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dtn7/dtn7/bpa"
+	"github.com/dtn7/dtn7/bundle"
+)
+
+// BundleSender is the part of the node's core this agent needs in order to
+// dispatch bundles built on behalf of a connected application.
+type BundleSender interface {
+	Send(bndl bundle.Bundle) error
+}
+
+// Server implements DtnAgentServer. It translates protobuf messages into
+// calls on the existing Builder/NewBundle path and fans delivered bundles
+// out to the applications registered for their destination endpoint.
+type Server struct {
+	sender BundleSender
+
+	mu            sync.Mutex
+	subscriptions map[bpa.EndpointID][]chan bundle.Bundle
+}
+
+// NewServer creates a Server which dispatches submitted bundles through the
+// given BundleSender.
+func NewServer(sender BundleSender) *Server {
+	return &Server{
+		sender:        sender,
+		subscriptions: make(map[bpa.EndpointID][]chan bundle.Bundle),
+	}
+}
+
+// Submit builds a bundle from a SubmitRequest and hands it to the node.
+func (s *Server) Submit(_ context.Context, req *SubmitRequest) (*SubmitResponse, error) {
+	src, err := endpointIDFromPb(req.GetSource())
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: invalid source: %v", err)
+	}
+
+	dst, err := endpointIDFromPb(req.GetDestination())
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: invalid destination: %v", err)
+	}
+
+	bndl, err := bundle.Builder().
+		CRC(bundle.CRCType(req.GetCrcType())).
+		Source(src).
+		Destination(dst).
+		CreationTimestampEpoch().
+		Lifetime(req.GetLifetime()).
+		PayloadBlock(req.GetPayload()).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: building bundle: %v", err)
+	}
+
+	if err := s.sender.Send(bndl); err != nil {
+		return nil, fmt.Errorf("grpc agent: dispatching bundle: %v", err)
+	}
+
+	return &SubmitResponse{BundleId: bndl.ID().String()}, nil
+}
+
+// Register streams bundles delivered for eid to the caller until the
+// stream's context is cancelled or encoding or sending a bundle fails.
+// Bundles delivered while the caller's 16-bundle buffer is full are dropped
+// by Delivered and never reach the stream.
+func (s *Server) Register(eid *EndpointID, stream DtnAgent_RegisterServer) error {
+	ep, err := endpointIDFromPb(eid)
+	if err != nil {
+		return fmt.Errorf("grpc agent: invalid endpoint: %v", err)
+	}
+
+	ch := make(chan bundle.Bundle, 16)
+	s.subscribe(ep, ch)
+	defer s.unsubscribe(ep, ch)
+
+	for {
+		select {
+		case bndl := <-ch:
+			pbBndl, err := bundleToPb(bndl)
+			if err != nil {
+				return fmt.Errorf("grpc agent: encoding bundle: %v", err)
+			}
+			if err := stream.Send(pbBndl); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Delivered is called by the node for each bundle addressed to a locally
+// registered endpoint, fanning it out to every application subscribed to
+// that endpoint via Register.
+func (s *Server) Delivered(bndl bundle.Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dst := bndl.PrimaryBlock.Destination
+	for _, ch := range s.subscriptions[dst] {
+		select {
+		case ch <- bndl:
+		default:
+			// Slow subscriber; drop the bundle rather than block delivery.
+		}
+	}
+}
+
+func (s *Server) subscribe(eid bpa.EndpointID, ch chan bundle.Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions[eid] = append(s.subscriptions[eid], ch)
+}
+
+func (s *Server) unsubscribe(eid bpa.EndpointID, ch chan bundle.Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chans := s.subscriptions[eid]
+	for i, c := range chans {
+		if c == ch {
+			s.subscriptions[eid] = append(chans[:i], chans[i+1:]...)
+			break
+		}
+	}
+}
+
+// endpointIDFromPb converts a protobuf EndpointID into a bpa.EndpointID.
+func endpointIDFromPb(eid *EndpointID) (bpa.EndpointID, error) {
+	switch ssp := eid.GetSsp().(type) {
+	case *EndpointID_Dtn:
+		if ssp.Dtn.GetSsp() == "" {
+			return bpa.DtnNone(), nil
+		}
+		return bpa.NewEndpointID("dtn", ssp.Dtn.GetSsp())
+
+	case *EndpointID_Ipn:
+		return bpa.NewEndpointID("ipn", fmt.Sprintf("%d.%d", ssp.Ipn.GetNode(), ssp.Ipn.GetService()))
+
+	default:
+		return bpa.EndpointID{}, fmt.Errorf("grpc agent: endpoint has no scheme-specific part")
+	}
+}
+
+// endpointIDToPb converts a bpa.EndpointID into its protobuf representation.
+// The schema only has wire messages for the "dtn" and "ipn" schemes; any
+// other scheme (e.g. one plugged in via bpa.RegisterScheme) is reported as
+// an error rather than silently relabelled as "dtn".
+func endpointIDToPb(eid bpa.EndpointID) (*EndpointID, error) {
+	if ipnSSP, ok := eid.SchemeSpecificPort.(bpa.IpnSSP); ok && eid.SchemeName == bpa.URISchemeIPN {
+		return &EndpointID{Ssp: &EndpointID_Ipn{Ipn: &IpnSSP{Node: ipnSSP.Node, Service: ipnSSP.Service}}}, nil
+	}
+
+	if eid.SchemeName != bpa.URISchemeDTN {
+		return nil, fmt.Errorf("grpc agent: endpoint scheme %d has no wire representation", eid.SchemeName)
+	}
+
+	var ssp string
+	if eid.SchemeSpecificPort != nil && eid.SchemeSpecificPort.String() != "none" {
+		ssp = eid.SchemeSpecificPort.String()
+	}
+	return &EndpointID{Ssp: &EndpointID_Dtn{Dtn: &DtnSSP{Ssp: ssp}}}, nil
+}
+
+// bundleToPb converts a bundle.Bundle into its protobuf representation.
+// Canonical blocks without a native message fall back to their CBOR
+// encoding, reusing the bundle package's own marshalling.
+func bundleToPb(bndl bundle.Bundle) (*Bundle, error) {
+	destination, err := endpointIDToPb(bndl.PrimaryBlock.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: encoding destination: %v", err)
+	}
+	source, err := endpointIDToPb(bndl.PrimaryBlock.SourceNode)
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: encoding source: %v", err)
+	}
+	reportTo, err := endpointIDToPb(bndl.PrimaryBlock.ReportTo)
+	if err != nil {
+		return nil, fmt.Errorf("grpc agent: encoding report-to: %v", err)
+	}
+
+	pb := &Bundle{
+		BundleControlFlags:        uint64(bndl.PrimaryBlock.BundleControlFlags),
+		Destination:               destination,
+		Source:                    source,
+		ReportTo:                  reportTo,
+		CreationTimestampTime:     uint64(bndl.PrimaryBlock.CreationTimestamp.DtnTime()),
+		CreationTimestampSequence: bndl.PrimaryBlock.CreationTimestamp.SequenceNumber(),
+		Lifetime:                  bndl.PrimaryBlock.Lifetime,
+	}
+
+	for _, cb := range bndl.CanonicalBlocks {
+		pbBlock := &CanonicalBlock{
+			BlockNumber:       uint64(cb.BlockNumber),
+			BlockControlFlags: uint64(cb.BlockControlFlags),
+		}
+
+		switch block := cb.Value.(type) {
+		case *bundle.PayloadBlock:
+			pbBlock.Data = &CanonicalBlock_Payload{Payload: &PayloadBlock{Data: block.Data()}}
+
+		case *bundle.HopCountBlock:
+			limit, count := block.HopCount()
+			pbBlock.Data = &CanonicalBlock_HopCount{HopCount: &HopCountBlock{Limit: uint32(limit), Count: uint32(count)}}
+
+		case *bundle.BundleAgeBlock:
+			pbBlock.Data = &CanonicalBlock_BundleAge{BundleAge: &BundleAgeBlock{Age: block.Age()}}
+
+		default:
+			buff := new(bytes.Buffer)
+			if err := cb.MarshalCbor(buff); err != nil {
+				return nil, fmt.Errorf("grpc agent: marshalling unknown block type %d: %v", cb.BlockType, err)
+			}
+			pbBlock.Data = &CanonicalBlock_CborData{CborData: buff.Bytes()}
+		}
+
+		pb.CanonicalBlocks = append(pb.CanonicalBlocks, pbBlock)
+	}
+
+	return pb, nil
+}
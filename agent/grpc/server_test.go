@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dtn7/dtn7/bpa"
+	"github.com/dtn7/dtn7/bundle"
+)
+
+// fakeSender is a BundleSender that records every bundle it is asked to
+// send, for assertions in tests.
+type fakeSender struct {
+	sent []bundle.Bundle
+}
+
+func (s *fakeSender) Send(bndl bundle.Bundle) error {
+	s.sent = append(s.sent, bndl)
+	return nil
+}
+
+// dialServer starts srv on an in-memory listener and returns a client
+// connected to it.
+func dialServer(t *testing.T, srv *Server) (DtnAgentClient, func()) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterDtnAgentServer(s, srv)
+	go s.Serve(lis)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+
+	return NewDtnAgentClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestServerSubmit(t *testing.T) {
+	sender := new(fakeSender)
+	srv := NewServer(sender)
+	client, closeFn := dialServer(t, srv)
+	defer closeFn()
+
+	resp, err := client.Submit(context.Background(), &SubmitRequest{
+		Source:      &EndpointID{Ssp: &EndpointID_Dtn{Dtn: &DtnSSP{Ssp: "myself"}}},
+		Destination: &EndpointID{Ssp: &EndpointID_Dtn{Dtn: &DtnSSP{Ssp: "dest"}}},
+		Lifetime:    1000,
+		Payload:     []byte("hello world!"),
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if resp.GetBundleId() == "" {
+		t.Fatalf("Submit returned an empty bundle ID")
+	}
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected one dispatched bundle, got %d", len(sender.sent))
+	}
+	if got := sender.sent[0].PrimaryBlock.Destination.String(); got != "dtn:dest" {
+		t.Fatalf("unexpected destination: %v", got)
+	}
+}
+
+func TestServerRegisterAndDelivered(t *testing.T) {
+	sender := new(fakeSender)
+	srv := NewServer(sender)
+	client, closeFn := dialServer(t, srv)
+	defer closeFn()
+
+	dst, err := bpa.NewEndpointID("dtn", "dest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Register(ctx, &EndpointID{Ssp: &EndpointID_Dtn{Dtn: &DtnSSP{Ssp: "dest"}}})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	// Give the server a moment to install the subscription before delivering.
+	waitForSubscriber(t, srv, dst)
+
+	bndl, err := bundle.Builder().
+		CRC(bundle.CRCNo).
+		Source("dtn://myself/").
+		Destination("dtn:dest").
+		CreationTimestampEpoch().
+		Lifetime("1m").
+		PayloadBlock([]byte("delivered!")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Delivered(bndl)
+
+	recv, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if len(recv.CanonicalBlocks) == 0 {
+		t.Fatalf("delivered bundle carried no canonical blocks")
+	}
+	payload, ok := recv.CanonicalBlocks[0].GetData().(*CanonicalBlock_Payload)
+	if !ok {
+		t.Fatalf("expected a payload block, got %T", recv.CanonicalBlocks[0].GetData())
+	}
+	if string(payload.Payload.Data) != "delivered!" {
+		t.Fatalf("unexpected payload: %q", payload.Payload.Data)
+	}
+}
+
+func TestEndpointIDToPbUnknownScheme(t *testing.T) {
+	const imcScheme = "imc"
+	const imcCode = uint(101)
+
+	bpa.RegisterScheme(imcScheme, imcCode,
+		func(ssp string) (bpa.EndpointSSP, error) { return nil, fmt.Errorf("stub: unused") },
+		func(value interface{}) (bpa.EndpointSSP, error) { return nil, fmt.Errorf("stub: unused") })
+
+	eid := bpa.EndpointID{SchemeName: imcCode}
+	if _, err := endpointIDToPb(eid); err == nil {
+		t.Fatalf("endpointIDToPb accepted an endpoint with an unrepresentable scheme")
+	}
+}
+
+// waitForSubscriber polls until eid has at least one subscriber, so the
+// test doesn't race Register's goroutine installing the subscription.
+func waitForSubscriber(t *testing.T, srv *Server, eid bpa.EndpointID) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		srv.mu.Lock()
+		n := len(srv.subscriptions[eid])
+		srv.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for Register's subscription")
+}
@@ -2,10 +2,12 @@ package bpa
 
 import (
 	"fmt"
-	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/ugorji/go/codec"
 )
 
 const (
@@ -13,101 +15,238 @@ const (
 	URISchemeIPN uint = 2
 )
 
-// EndpointID represents an Endpoint ID as defined in section 4.1.5.1. The
-// "scheme name" is represented by an uint (vide supra) and the "scheme-specific
-// part" (SSP) by an interface{}. Based on the characteristic of the name, the
-// underlying type of the SSP may vary.
-type EndpointID struct {
-	_struct struct{} `codec:",toarray"`
+// EndpointSSP is the "scheme-specific part" (SSP) of an EndpointID. Each
+// supported URI scheme provides its own implementation and is plugged in
+// through RegisterScheme, so the CBOR decode path finds a scheme's decoder
+// by looking up its code rather than through reflection.
+//
+// Implementations must be value types with value-receiver methods, not
+// pointer types: EndpointID relies on EndpointSSP's dynamic value being
+// directly comparable so that two EndpointIDs built from the same logical
+// URI are == and interchangeable as map keys.
+type EndpointSSP interface {
+	// SchemeName returns the uint code of the scheme this SSP belongs to.
+	SchemeName() uint
 
-	SchemeName         uint
-	SchemeSpecificPort interface{}
+	// MarshalCborValue returns this SSP's representation for CBOR encoding.
+	MarshalCborValue() interface{}
+
+	String() string
+}
+
+type schemeEntry struct {
+	code   uint
+	parse  func(ssp string) (EndpointSSP, error)
+	decode func(value interface{}) (EndpointSSP, error)
+}
+
+var (
+	schemesMu     sync.RWMutex
+	schemesByName = make(map[string]schemeEntry)
+	schemesByCode = make(map[uint]schemeEntry)
+)
+
+// RegisterScheme registers a URI scheme for use within EndpointIDs. It lets
+// downstream code plug in additional schemes (e.g. an "imc:" multicast
+// scheme, or a future secure-endpoint scheme) without editing this package.
+// parse creates an EndpointSSP from the textual SSP of a "name:ssp" URI,
+// decode creates one from an already CBOR-decoded value.
+func RegisterScheme(name string, code uint, parse func(string) (EndpointSSP, error), decode func(interface{}) (EndpointSSP, error)) {
+	entry := schemeEntry{code: code, parse: parse, decode: decode}
+
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+
+	schemesByName[name] = entry
+	schemesByCode[code] = entry
+}
+
+func init() {
+	RegisterScheme("dtn", URISchemeDTN, parseDtnSSP, decodeDtnSSP)
+	RegisterScheme("ipn", URISchemeIPN, parseIpnSSP, decodeIpnSSP)
 }
 
-func newEndpointIDDTN(ssp string) (EndpointID, error) {
-	var sspRaw interface{}
+// DtnSSP is the scheme-specific part of a "dtn" URI: either the literal
+// "none" or an arbitrary string.
+type DtnSSP struct {
+	none bool
+	ssp  string
+}
+
+func parseDtnSSP(ssp string) (EndpointSSP, error) {
 	if ssp == "none" {
-		sspRaw = uint(0)
-	} else {
-		sspRaw = string(ssp)
+		return DtnSSP{none: true}, nil
 	}
+	return DtnSSP{ssp: ssp}, nil
+}
 
-	return EndpointID{
-		SchemeName:         URISchemeDTN,
-		SchemeSpecificPort: sspRaw,
-	}, nil
+func decodeDtnSSP(value interface{}) (EndpointSSP, error) {
+	switch v := value.(type) {
+	case uint64:
+		if v != 0 {
+			return nil, newBPAError(fmt.Sprintf("dtn SSP encoded as uint must be 0, got %d", v))
+		}
+		return DtnSSP{none: true}, nil
+
+	case string:
+		return DtnSSP{ssp: v}, nil
+
+	default:
+		return nil, newBPAError(fmt.Sprintf("dtn SSP has unexpected CBOR type %T", value))
+	}
 }
 
-func newEndpointIDIPN(ssp string) (ep EndpointID, err error) {
-	// As definied in RFC 6260, section 2.1:
-	// - node number: ASCII numeric digits between 1 and (2^64-1)
-	// - an ASCII dot
-	// - service number: ASCII numeric digits between 1 and (2^64-1)
+func (ssp DtnSSP) SchemeName() uint { return URISchemeDTN }
 
+func (ssp DtnSSP) MarshalCborValue() interface{} {
+	if ssp.none {
+		return uint(0)
+	}
+	return ssp.ssp
+}
+
+func (ssp DtnSSP) String() string {
+	if ssp.none {
+		return "none"
+	}
+	return ssp.ssp
+}
+
+// IpnSSP is the scheme-specific part of an "ipn" URI, as defined in RFC
+// 6260, section 2.1: a node number and a service number, both in the range
+// [1, 2^64-1].
+type IpnSSP struct {
+	Node    uint64
+	Service uint64
+}
+
+func parseIpnSSP(ssp string) (EndpointSSP, error) {
 	re := regexp.MustCompile(`^(\d+)\.(\d+)$`)
 	matches := re.FindStringSubmatch(ssp)
 	if len(matches) != 3 {
-		err = newBPAError("IPN does not satisfy given regex")
-		return
+		return nil, newBPAError("IPN does not satisfy given regex")
 	}
 
 	nodeNo, err := strconv.ParseUint(matches[1], 10, 64)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	serviceNo, err := strconv.ParseUint(matches[2], 10, 64)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	if nodeNo < 1 || serviceNo < 1 {
-		err = newBPAError("IPN's node and service number must be >= 1")
-		return
+		return nil, newBPAError("IPN's node and service number must be >= 1")
+	}
+
+	return IpnSSP{Node: nodeNo, Service: serviceNo}, nil
+}
+
+func decodeIpnSSP(value interface{}) (EndpointSSP, error) {
+	arr, ok := value.([]interface{})
+	if !ok || len(arr) != 2 {
+		return nil, newBPAError(fmt.Sprintf("ipn SSP has unexpected CBOR value %v", value))
+	}
+
+	node, ok := arr[0].(uint64)
+	if !ok {
+		return nil, newBPAError("ipn SSP's node number is not an uint")
 	}
 
-	ep = EndpointID{
-		SchemeName:         URISchemeIPN,
-		SchemeSpecificPort: [2]uint64{nodeNo, serviceNo},
+	service, ok := arr[1].(uint64)
+	if !ok {
+		return nil, newBPAError("ipn SSP's service number is not an uint")
 	}
-	return
+
+	return IpnSSP{Node: node, Service: service}, nil
+}
+
+func (ssp IpnSSP) SchemeName() uint { return URISchemeIPN }
+
+func (ssp IpnSSP) MarshalCborValue() interface{} {
+	return []interface{}{ssp.Node, ssp.Service}
+}
+
+func (ssp IpnSSP) String() string {
+	return fmt.Sprintf("%d.%d", ssp.Node, ssp.Service)
+}
+
+// EndpointID represents an Endpoint ID as defined in section 4.1.5.1. The
+// "scheme name" is represented by an uint (vide supra) and the
+// "scheme-specific part" (SSP) by an EndpointSSP, whose concrete type
+// depends on the scheme.
+type EndpointID struct {
+	SchemeName         uint
+	SchemeSpecificPort EndpointSSP
 }
 
 // NewEndpointID creates a new EndpointID by a given "scheme name" and a
-// "scheme-specific part" (SSP). Currently the "dtn" and "ipn"-scheme names
-// are supported.
+// "scheme-specific part" (SSP). The supported scheme names are those
+// registered through RegisterScheme; "dtn" and "ipn" are registered by
+// default.
 func NewEndpointID(name, ssp string) (EndpointID, error) {
-	switch name {
-	case "dtn":
-		return newEndpointIDDTN(ssp)
-	case "ipn":
-		return newEndpointIDIPN(ssp)
-	default:
+	schemesMu.RLock()
+	entry, ok := schemesByName[name]
+	schemesMu.RUnlock()
+
+	if !ok {
 		return EndpointID{}, newBPAError("Unknown scheme type")
 	}
+
+	sspVal, err := entry.parse(ssp)
+	if err != nil {
+		return EndpointID{}, err
+	}
+
+	return EndpointID{SchemeName: entry.code, SchemeSpecificPort: sspVal}, nil
+}
+
+// CodecEncodeSelf implements codec.Selfer, encoding the EndpointID as its
+// two-element CBOR array of scheme name and SSP.
+func (eid EndpointID) CodecEncodeSelf(enc *codec.Encoder) {
+	var sspVal interface{}
+	if eid.SchemeSpecificPort != nil {
+		sspVal = eid.SchemeSpecificPort.MarshalCborValue()
+	}
+
+	enc.MustEncode([]interface{}{eid.SchemeName, sspVal})
+}
+
+// CodecDecodeSelf implements codec.Selfer, decoding the EndpointID from its
+// two-element CBOR array of scheme name and SSP.
+func (eid *EndpointID) CodecDecodeSelf(dec *codec.Decoder) {
+	var arr []interface{}
+	dec.MustDecode(&arr)
+
+	if err := setEndpointIDFromCborArray(eid, arr); err != nil {
+		panic(err)
+	}
 }
 
 // setEndpointIDFromCborArray sets the fields of the EndpointID addressed by
-// the EndpointID-pointer based on the given array. This function is used for
-// the CBOR decoding of the PrimaryBlock and some Extension Blocks.
-func setEndpointIDFromCborArray(ep *EndpointID, arr []interface{}) {
-	(*ep).SchemeName = uint(arr[0].(uint64))
-	(*ep).SchemeSpecificPort = arr[1]
-
-	// The codec library uses uint64 for uints and []interface{} for arrays
-	// internally. However, our `dtn:none` is defined by an uint and each "ipn"
-	// endpoint by an uint64 array. That's why we have to re-cast some types..
-
-	switch ty := reflect.TypeOf((*ep).SchemeSpecificPort); ty.Kind() {
-	case reflect.Uint64:
-		(*ep).SchemeSpecificPort = uint((*ep).SchemeSpecificPort.(uint64))
-
-	case reflect.Slice:
-		(*ep).SchemeSpecificPort = [2]uint64{
-			(*ep).SchemeSpecificPort.([]interface{})[0].(uint64),
-			(*ep).SchemeSpecificPort.([]interface{})[1].(uint64),
-		}
+// the EndpointID-pointer based on the given array. This function is used
+// for the CBOR decoding of the PrimaryBlock and some Extension Blocks.
+func setEndpointIDFromCborArray(ep *EndpointID, arr []interface{}) error {
+	code := uint(arr[0].(uint64))
+
+	schemesMu.RLock()
+	entry, ok := schemesByCode[code]
+	schemesMu.RUnlock()
+
+	if !ok {
+		return newBPAError(fmt.Sprintf("unknown scheme code %d", code))
 	}
+
+	ssp, err := entry.decode(arr[1])
+	if err != nil {
+		return err
+	}
+
+	ep.SchemeName = code
+	ep.SchemeSpecificPort = ssp
+	return nil
 }
 
 func (eid EndpointID) String() string {
@@ -123,27 +262,8 @@ func (eid EndpointID) String() string {
 	}
 	b.WriteRune(':')
 
-	switch t := eid.SchemeSpecificPort.(type) {
-	case uint:
-		if eid.SchemeName == URISchemeDTN && eid.SchemeSpecificPort.(uint) == 0 {
-			b.WriteString("none")
-		} else {
-			fmt.Fprintf(&b, "%d", eid.SchemeSpecificPort.(uint))
-		}
-
-	case string:
-		b.WriteString(eid.SchemeSpecificPort.(string))
-
-	case [2]uint64:
-		var ssp [2]uint64 = eid.SchemeSpecificPort.([2]uint64)
-		if eid.SchemeName == URISchemeIPN {
-			fmt.Fprintf(&b, "%d.%d", ssp[0], ssp[1])
-		} else {
-			fmt.Fprintf(&b, "%v", ssp)
-		}
-
-	default:
-		fmt.Fprintf(&b, "unkown %T: %v", t, eid.SchemeSpecificPort)
+	if eid.SchemeSpecificPort != nil {
+		b.WriteString(eid.SchemeSpecificPort.String())
 	}
 
 	return b.String()
@@ -153,6 +273,6 @@ func (eid EndpointID) String() string {
 func DtnNone() EndpointID {
 	return EndpointID{
 		SchemeName:         URISchemeDTN,
-		SchemeSpecificPort: uint(0),
+		SchemeSpecificPort: DtnSSP{none: true},
 	}
 }
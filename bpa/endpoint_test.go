@@ -0,0 +1,100 @@
+package bpa
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestEndpointIDEquality(t *testing.T) {
+	a, err := NewEndpointID("dtn", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewEndpointID("dtn", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != b {
+		t.Fatalf("NewEndpointID(x, y) != NewEndpointID(x, y): %v != %v", a, b)
+	}
+
+	m := map[EndpointID]int{a: 42}
+	if v, ok := m[b]; !ok || v != 42 {
+		t.Fatalf("map lookup with an equal EndpointID failed: %v, %v", v, ok)
+	}
+}
+
+func TestEndpointIDCborRoundTrip(t *testing.T) {
+	tests := []EndpointID{
+		DtnNone(),
+		mustNewEndpointID(t, "dtn", "foo/bar"),
+		mustNewEndpointID(t, "ipn", "5.2"),
+	}
+
+	for _, eidIn := range tests {
+		var buf bytes.Buffer
+		if err := codec.NewEncoder(&buf, new(codec.CborHandle)).Encode(eidIn); err != nil {
+			t.Fatalf("encoding %v: %v", eidIn, err)
+		}
+
+		var eidOut EndpointID
+		if err := codec.NewDecoder(&buf, new(codec.CborHandle)).Decode(&eidOut); err != nil {
+			t.Fatalf("decoding %v: %v", eidIn, err)
+		}
+
+		if eidOut != eidIn {
+			t.Fatalf("decoded endpoint does not match: %v != %v", eidOut, eidIn)
+		}
+
+		m := map[EndpointID]bool{eidIn: true}
+		if !m[eidOut] {
+			t.Fatalf("decoded endpoint %v does not hit as a map key for %v", eidOut, eidIn)
+		}
+	}
+}
+
+func TestRegisterScheme(t *testing.T) {
+	const imcScheme = "imc"
+	const imcCode = uint(100)
+
+	RegisterScheme(imcScheme, imcCode,
+		func(ssp string) (EndpointSSP, error) { return DtnSSP{ssp: ssp}, nil },
+		func(value interface{}) (EndpointSSP, error) { return decodeDtnSSP(value) })
+
+	eid, err := NewEndpointID(imcScheme, "group42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eid.SchemeName != imcCode {
+		t.Fatalf("unexpected scheme code: %d", eid.SchemeName)
+	}
+	if eid.SchemeSpecificPort.String() != "group42" {
+		t.Fatalf("unexpected SSP: %v", eid.SchemeSpecificPort)
+	}
+
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, new(codec.CborHandle)).Encode(eid); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded EndpointID
+	if err := codec.NewDecoder(&buf, new(codec.CborHandle)).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != eid {
+		t.Fatalf("decoded endpoint does not match: %v != %v", decoded, eid)
+	}
+}
+
+func mustNewEndpointID(t *testing.T, name, ssp string) EndpointID {
+	t.Helper()
+
+	eid, err := NewEndpointID(name, ssp)
+	if err != nil {
+		t.Fatalf("NewEndpointID(%q, %q): %v", name, ssp, err)
+	}
+	return eid
+}
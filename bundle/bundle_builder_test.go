@@ -70,6 +70,14 @@ func TestBundleBuilderSimple(t *testing.T) {
 	}
 }
 
+// TODO(bundle): this whole package's implementation (Builder, EndpointID,
+// PrimaryBlock, NewBundle, etc.) is not present in this checkout, so every
+// test in this file - not just this one - fails to compile until it lands.
+// When it does, route bldrParseEndpoint and the CBOR decode path for
+// EndpointID through bpa.EndpointSSP/bpa.RegisterScheme (see
+// bpa/endpoint.go) rather than assuming a fixed SSP shape, so schemes
+// registered via RegisterScheme keep working end-to-end instead of
+// panicking or silently dropping a decode error.
 func TestBldrParseEndpoint(t *testing.T) {
 	eidIn, _ := NewEndpointID("dtn://foo/bar/")
 	if eidTmp, _ := bldrParseEndpoint(eidIn); eidTmp != eidIn {